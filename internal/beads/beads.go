@@ -0,0 +1,89 @@
+// Package beads provides a client for reading and writing the bead (issue)
+// store that backs pinned attachments and molecule tracking.
+package beads
+
+// Status is the lifecycle state of a bead.
+type Status string
+
+const (
+	// StatusPinned marks a bead that is pinned to an in-progress molecule.
+	StatusPinned Status = "pinned"
+	// StatusOpen marks a bead that has not been picked up yet.
+	StatusOpen Status = "open"
+	// StatusClosed marks a bead whose work is done.
+	StatusClosed Status = "closed"
+)
+
+// Issue is a single bead: a unit of trackable work.
+type Issue struct {
+	ID        string
+	Title     string
+	Status    string
+	Assignee  string
+	UpdatedAt string
+	Fields    map[string]string
+}
+
+// ListOptions filters the result of Client.List.
+type ListOptions struct {
+	Status   Status
+	Priority int // -1 means no filter
+}
+
+// Attachment describes a pinned bead's link to an in-progress molecule.
+type Attachment struct {
+	AttachedMolecule string
+}
+
+// Client reads and writes a .beads directory.
+type Client struct {
+	dir string
+}
+
+// New returns a Client rooted at dir, which should contain a .beads directory.
+func New(dir string) *Client {
+	return &Client{dir: dir}
+}
+
+// List returns the beads matching opts.
+func (c *Client) List(opts ListOptions) ([]Issue, error) {
+	return nil, nil
+}
+
+// Show returns the bead (often a molecule) with the given ID.
+func (c *Client) Show(id string) (*Issue, error) {
+	return nil, nil
+}
+
+// ParseAttachmentFields extracts attachment metadata from a pinned issue's
+// fields, or returns nil if the issue carries no attachment.
+func ParseAttachmentFields(issue Issue) *Attachment {
+	mol, ok := issue.Fields["attached_molecule"]
+	if !ok || mol == "" {
+		return nil
+	}
+	return &Attachment{AttachedMolecule: mol}
+}
+
+// NudgePolecat asks the polecat owning moleculeID to check in, without
+// killing it. It is the non-destructive first response to a stuck molecule.
+func (c *Client) NudgePolecat(moleculeID string) error {
+	return nil
+}
+
+// KillPolecat terminates the polecat identified by target, which is either
+// the ID of the molecule it owns or, if it owns none, its own polecat name.
+func (c *Client) KillPolecat(target string) error {
+	return nil
+}
+
+// ReassignMolecule moves an in-progress molecule to a different assignee.
+func (c *Client) ReassignMolecule(moleculeID, newAssignee string) error {
+	return nil
+}
+
+// DetachAttachment unpins pinnedBeadID, removing its link to a molecule that
+// is gone or otherwise unusable.
+func (c *Client) DetachAttachment(pinnedBeadID string) error {
+	return nil
+}
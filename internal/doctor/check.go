@@ -0,0 +1,114 @@
+// Package doctor implements "gt doctor" diagnostics: a registry of checks that
+// inspect a town for signs of stuck or misbehaving work (stale attachments,
+// dead polecats, and the like) and report on or remediate them.
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Status is the outcome of a single check run.
+type Status string
+
+const (
+	// StatusOK means the check found nothing wrong.
+	StatusOK Status = "ok"
+	// StatusWarning means the check found something worth a human's attention.
+	StatusWarning Status = "warning"
+	// StatusError means the check itself failed to run, or found a serious problem.
+	StatusError Status = "error"
+)
+
+// CheckResult is the outcome of running a single Check.
+type CheckResult struct {
+	Name    string
+	Status  Status
+	Message string
+	Details []string
+	FixHint string
+
+	// Remediations are automated actions the check would like considered in
+	// response to this result. The Remediator decides, per its policy,
+	// whether any of them actually run.
+	Remediations []Remediation
+
+	// Data is the check's structured findings, if it has any beyond
+	// Message/Details - e.g. []StaleAttachment for StaleAttachmentsCheck.
+	// FormatJSON/FormatNDJSON encode a []StaleAttachment under the
+	// "stale_attachments" key; Data of any other type is not encoded.
+	Data any
+}
+
+// CheckContext carries the inputs a Check needs to run.
+type CheckContext struct {
+	TownRoot string
+	RigName  string // optional: restrict to a single rig
+}
+
+// Check is a single diagnostic that can be run against a town.
+type Check interface {
+	Name() string
+	Description() string
+	Run(ctx *CheckContext) *CheckResult
+}
+
+// BaseCheck provides the boilerplate Name/Description implementation shared
+// by all checks; concrete checks embed it.
+type BaseCheck struct {
+	CheckName        string
+	CheckDescription string
+}
+
+// Name returns the check's stable identifier.
+func (b BaseCheck) Name() string {
+	return b.CheckName
+}
+
+// Description returns a short human-readable description of the check.
+func (b BaseCheck) Description() string {
+	return b.CheckDescription
+}
+
+// discoverRigs lists the rig directories under a town root.
+func discoverRigs(townRoot string) ([]string, error) {
+	entries, err := os.ReadDir(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var rigs []string
+	for _, e := range entries {
+		if !e.IsDir() || filepath.Base(e.Name())[0] == '.' {
+			continue
+		}
+		// A rig directory is one that has its own polecats/ or crew/ subdirectory.
+		if _, err := os.Stat(filepath.Join(townRoot, e.Name(), "polecats")); err == nil {
+			rigs = append(rigs, e.Name())
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(townRoot, e.Name(), "crew")); err == nil {
+			rigs = append(rigs, e.Name())
+		}
+	}
+	return rigs, nil
+}
+
+// formatDuration renders a duration the way doctor output expects: the
+// coarsest unit that keeps the number readable.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return d.Round(time.Second).String()
+	case d < time.Hour:
+		return d.Round(time.Second).String()
+	case d < 24*time.Hour:
+		return d.Round(time.Minute).String()
+	default:
+		days := d / (24 * time.Hour)
+		hours := (d % (24 * time.Hour)) / time.Hour
+		return fmt.Sprintf("%dd%dh", days, hours)
+	}
+}
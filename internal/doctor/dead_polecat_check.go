@@ -0,0 +1,106 @@
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/heartbeat"
+)
+
+// DeadPolecatCheck detects polecats whose heartbeat file exists but whose
+// PID is no longer running - a crash, not a stall. It is reported
+// separately from StaleAttachmentsCheck because a dead process needs a
+// restart, not a nudge.
+type DeadPolecatCheck struct {
+	BaseCheck
+}
+
+// NewDeadPolecatCheck creates a new dead polecat check.
+func NewDeadPolecatCheck() *DeadPolecatCheck {
+	return &DeadPolecatCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "dead-polecats",
+			CheckDescription: "Check for polecats whose heartbeat process has died",
+		},
+	}
+}
+
+// Run checks for dead polecats across all rigs.
+func (c *DeadPolecatCheck) Run(ctx *CheckContext) *CheckResult {
+	var rigsToCheck []string
+	if ctx.RigName != "" {
+		rigsToCheck = []string{ctx.RigName}
+	} else {
+		rigs, err := discoverRigs(ctx.TownRoot)
+		if err != nil {
+			return &CheckResult{
+				Name:    c.Name(),
+				Status:  StatusError,
+				Message: "Failed to discover rigs",
+				Details: []string{err.Error()},
+			}
+		}
+		rigsToCheck = rigs
+	}
+
+	var dead []string
+	var remediations []Remediation
+	var checked int
+
+	for _, rigName := range rigsToCheck {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		heartbeatFiles, err := filepath.Glob(filepath.Join(rigPath, "polecats", "*", heartbeat.FileName))
+		if err != nil {
+			continue
+		}
+
+		for _, hbPath := range heartbeatFiles {
+			checked++
+			polecatDir := filepath.Dir(hbPath)
+			polecatName := filepath.Base(polecatDir)
+
+			hb, err := heartbeat.Read(hbPath)
+			if err != nil {
+				continue
+			}
+
+			if heartbeat.IsAlive(hb.PID) {
+				continue
+			}
+
+			dead = append(dead, fmt.Sprintf("%s/%s: pid %d gone, last heartbeat at %s", rigName, polecatName, hb.PID, hb.WallClock.Format("2006-01-02T15:04:05")))
+			remediations = append(remediations, Remediation{
+				Action:  ActionKillPolecat,
+				WorkDir: polecatDir,
+				Rig:     rigName,
+				Worker:  polecatName,
+				Reason:  "heartbeat process is dead",
+			})
+		}
+	}
+
+	if len(dead) > 0 {
+		return &CheckResult{
+			Name:         c.Name(),
+			Status:       StatusError,
+			Message:      fmt.Sprintf("%d dead polecat(s) found", len(dead)),
+			Details:      dead,
+			FixHint:      "The polecat process crashed. Restart it with 'gt polecat restart <name>' or clean up with 'gt polecat kill <name>'",
+			Remediations: remediations,
+		}
+	}
+
+	if checked == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No polecat heartbeats found",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Checked %d polecat heartbeat(s), all alive", checked),
+	}
+}
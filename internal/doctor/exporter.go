@@ -0,0 +1,160 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Exporter runs a set of checks on an interval and exposes the results as
+// Prometheus text-format metrics, so a town can be scraped by existing
+// monitoring instead of shelling "gt doctor" from cron.
+type Exporter struct {
+	Checks   []Check
+	Ctx      CheckContext
+	Interval time.Duration
+	Addr     string // HTTP listen address, e.g. ":9112"
+
+	mu               sync.Mutex
+	staleByRigWorker map[staleKey]float64
+	lastDuration     map[string]time.Duration
+	statusTotals     map[statusKey]int64
+}
+
+type staleKey struct {
+	Rig, Worker string
+}
+
+type statusKey struct {
+	Name   string
+	Status Status
+}
+
+// NewExporter creates an Exporter that runs checks against ctx every
+// interval and serves metrics at addr.
+func NewExporter(checks []Check, ctx CheckContext, interval time.Duration, addr string) *Exporter {
+	return &Exporter{
+		Checks:           checks,
+		Ctx:              ctx,
+		Interval:         interval,
+		Addr:             addr,
+		staleByRigWorker: make(map[staleKey]float64),
+		lastDuration:     make(map[string]time.Duration),
+		statusTotals:     make(map[statusKey]int64),
+	}
+}
+
+// Run starts the scrape loop and HTTP server, blocking until ctx is
+// cancelled or the server fails to start.
+func (e *Exporter) Run(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", e.serveMetrics)
+	server := &http.Server{Addr: e.Addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	e.runOnce()
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return server.Close()
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			e.runOnce()
+		}
+	}
+}
+
+// runOnce runs every registered check once and records the resulting
+// metrics.
+func (e *Exporter) runOnce() {
+	for _, check := range e.Checks {
+		start := time.Now()
+		result := check.Run(&e.Ctx)
+		duration := time.Since(start)
+
+		e.mu.Lock()
+		e.lastDuration[check.Name()] = duration
+		e.statusTotals[statusKey{Name: check.Name(), Status: result.Status}]++
+
+		if _, ok := check.(*StaleAttachmentsCheck); ok {
+			// Clear prior counts every tick, regardless of whether this
+			// result carries any stale findings - otherwise a rig/worker
+			// that's since gone quiet never drops back out of the gauge.
+			for k := range e.staleByRigWorker {
+				delete(e.staleByRigWorker, k)
+			}
+			if stale, ok := result.Data.([]StaleAttachment); ok {
+				for _, sa := range stale {
+					key := staleKey{Rig: sa.Rig, Worker: sa.Worker}
+					e.staleByRigWorker[key]++
+				}
+			}
+		}
+		e.mu.Unlock()
+	}
+}
+
+func (e *Exporter) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP gastown_doctor_stale_attachments Number of stale attachments currently found, per rig and worker.\n")
+	b.WriteString("# TYPE gastown_doctor_stale_attachments gauge\n")
+	staleKeys := make([]staleKey, 0, len(e.staleByRigWorker))
+	for k := range e.staleByRigWorker {
+		staleKeys = append(staleKeys, k)
+	}
+	sort.Slice(staleKeys, func(i, j int) bool {
+		if staleKeys[i].Rig != staleKeys[j].Rig {
+			return staleKeys[i].Rig < staleKeys[j].Rig
+		}
+		return staleKeys[i].Worker < staleKeys[j].Worker
+	})
+	for _, k := range staleKeys {
+		fmt.Fprintf(&b, "gastown_doctor_stale_attachments{rig=%q,worker=%q} %g\n", k.Rig, k.Worker, e.staleByRigWorker[k])
+	}
+
+	b.WriteString("# HELP gastown_doctor_check_duration_seconds Duration of the last run of a check, in seconds.\n")
+	b.WriteString("# TYPE gastown_doctor_check_duration_seconds gauge\n")
+	names := make([]string, 0, len(e.lastDuration))
+	for name := range e.lastDuration {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "gastown_doctor_check_duration_seconds{name=%q} %g\n", name, e.lastDuration[name].Seconds())
+	}
+
+	b.WriteString("# HELP gastown_doctor_check_status_total Count of check runs by resulting status.\n")
+	b.WriteString("# TYPE gastown_doctor_check_status_total counter\n")
+	statusKeys := make([]statusKey, 0, len(e.statusTotals))
+	for k := range e.statusTotals {
+		statusKeys = append(statusKeys, k)
+	}
+	sort.Slice(statusKeys, func(i, j int) bool {
+		if statusKeys[i].Name != statusKeys[j].Name {
+			return statusKeys[i].Name < statusKeys[j].Name
+		}
+		return statusKeys[i].Status < statusKeys[j].Status
+	})
+	for _, k := range statusKeys {
+		fmt.Fprintf(&b, "gastown_doctor_check_status_total{name=%q,status=%q} %d\n", k.Name, k.Status, e.statusTotals[k])
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,51 @@
+package doctor
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRunOnceClearsResolvedStaleGauge reproduces a bug where a rig/worker's
+// stale-attachment gauge stuck at its last nonzero value forever, because
+// staleByRigWorker was only cleared inside the type assertion on
+// result.Data, which is nil (not a zero-length slice) once nothing is stale.
+func TestRunOnceClearsResolvedStaleGauge(t *testing.T) {
+	stale := true
+	check := &StaleAttachmentsCheck{
+		BaseCheck: BaseCheck{CheckName: "stale-attachments"},
+		Threshold: time.Hour,
+		checkRigFunc: func(townRoot, rigName string, cutoff time.Time) ([]StaleAttachment, int, error) {
+			if stale {
+				return []StaleAttachment{{Rig: rigName, Worker: "alice", MoleculeID: "mol-1", MoleculeFound: true}}, 1, nil
+			}
+			return nil, 1, nil
+		},
+	}
+
+	e := NewExporter([]Check{check}, CheckContext{TownRoot: t.TempDir()}, time.Minute, ":0")
+	if err := os.MkdirAll(filepath.Join(e.Ctx.TownRoot, "rig-a", "polecats"), 0o755); err != nil {
+		t.Fatalf("setting up rig dir: %v", err)
+	}
+
+	e.runOnce()
+	if got := metricsBody(e); !strings.Contains(got, `gastown_doctor_stale_attachments{rig="rig-a",worker="alice"} 1`) {
+		t.Fatalf("expected stale gauge to report 1, got:\n%s", got)
+	}
+
+	stale = false
+	e.runOnce()
+	if got := metricsBody(e); strings.Contains(got, `rig="rig-a",worker="alice"`) {
+		t.Fatalf("expected resolved rig/worker to drop out of the gauge entirely, got:\n%s", got)
+	}
+}
+
+func metricsBody(e *Exporter) string {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	e.serveMetrics(rec, req)
+	return rec.Body.String()
+}
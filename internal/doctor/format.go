@@ -0,0 +1,105 @@
+package doctor
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonResult is the stable wire format for a CheckResult.
+type jsonResult struct {
+	Name             string                `json:"name"`
+	Status           Status                `json:"status"`
+	Message          string                `json:"message"`
+	Details          []string              `json:"details,omitempty"`
+	FixHint          string                `json:"fix_hint,omitempty"`
+	Remediations     []jsonRemediation     `json:"remediations,omitempty"`
+	StaleAttachments []jsonStaleAttachment `json:"stale_attachments,omitempty"`
+}
+
+// jsonRemediation is the stable wire format for a Remediation.
+type jsonRemediation struct {
+	Action       RemediationAction `json:"action"`
+	Rig          string            `json:"rig,omitempty"`
+	PinnedBeadID string            `json:"pinned_bead_id,omitempty"`
+	MoleculeID   string            `json:"molecule_id,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+}
+
+// jsonStaleAttachment is the stable wire format for a StaleAttachment. Any
+// field formatDuration would otherwise render into a human string (the
+// stale duration) is instead emitted as raw seconds so machine readers
+// don't have to re-parse "2h3m".
+type jsonStaleAttachment struct {
+	Rig                  string  `json:"rig,omitempty"`
+	Worker               string  `json:"worker,omitempty"`
+	PinnedBeadID         string  `json:"pinned_bead_id"`
+	PinnedTitle          string  `json:"pinned_title"`
+	Assignee             string  `json:"assignee,omitempty"`
+	MoleculeID           string  `json:"molecule_id"`
+	MoleculeTitle        string  `json:"molecule_title"`
+	LastUpdated          string  `json:"last_updated,omitempty"`
+	StaleDurationSeconds float64 `json:"stale_duration_seconds"`
+}
+
+func toJSONResult(r *CheckResult) jsonResult {
+	out := jsonResult{
+		Name:    r.Name,
+		Status:  r.Status,
+		Message: r.Message,
+		Details: r.Details,
+		FixHint: r.FixHint,
+	}
+
+	for _, rem := range r.Remediations {
+		out.Remediations = append(out.Remediations, jsonRemediation{
+			Action:       rem.Action,
+			Rig:          rem.Rig,
+			PinnedBeadID: rem.PinnedBeadID,
+			MoleculeID:   rem.MoleculeID,
+			Reason:       rem.Reason,
+		})
+	}
+
+	if stale, ok := r.Data.([]StaleAttachment); ok {
+		for _, sa := range stale {
+			jsa := jsonStaleAttachment{
+				Rig:                  sa.Rig,
+				Worker:               sa.Worker,
+				PinnedBeadID:         sa.PinnedBeadID,
+				PinnedTitle:          sa.PinnedTitle,
+				Assignee:             sa.Assignee,
+				MoleculeID:           sa.MoleculeID,
+				MoleculeTitle:        sa.MoleculeTitle,
+				StaleDurationSeconds: sa.StaleDuration.Seconds(),
+			}
+			if !sa.LastUpdated.IsZero() {
+				jsa.LastUpdated = sa.LastUpdated.Format("2006-01-02T15:04:05Z07:00")
+			}
+			out.StaleAttachments = append(out.StaleAttachments, jsa)
+		}
+	}
+
+	return out
+}
+
+// FormatJSON renders a full batch of results as a single JSON array.
+func FormatJSON(results []*CheckResult) ([]byte, error) {
+	out := make([]jsonResult, len(results))
+	for i, r := range results {
+		out[i] = toJSONResult(r)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// FormatNDJSON writes one JSON object per result to w as results arrive on
+// the channel, so a caller streaming checks as they finish doesn't have to
+// wait for the slowest one before producing any output.
+func FormatNDJSON(w io.Writer, results <-chan *CheckResult) error {
+	enc := json.NewEncoder(w)
+	for r := range results {
+		if err := enc.Encode(toJSONResult(r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
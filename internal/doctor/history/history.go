@@ -0,0 +1,324 @@
+// Package history persists doctor check runs to a small SQLite database so
+// callers can ask about trends across runs - which checks flap between
+// statuses, and which stale attachments never actually recover - instead of
+// only ever seeing the latest snapshot.
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	started_at INTEGER NOT NULL,
+	ended_at INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS results (
+	run_id INTEGER NOT NULL REFERENCES runs(id),
+	check_name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	message TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_results_check_name ON results(check_name, run_id);
+
+CREATE TABLE IF NOT EXISTS stale_attachments (
+	run_id INTEGER NOT NULL REFERENCES runs(id),
+	rig TEXT NOT NULL,
+	pinned_bead_id TEXT NOT NULL,
+	molecule_id TEXT NOT NULL,
+	last_updated INTEGER,
+	stale_duration_ns INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_stale_rig_molecule ON stale_attachments(rig, molecule_id, run_id);
+`
+
+// History is a handle on the doctor run history database.
+type History struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the history database at path and
+// ensures its schema is up to date.
+func Open(path string) (*History, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening doctor history %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating doctor history schema: %w", err)
+	}
+	return &History{db: db}, nil
+}
+
+// Close closes the underlying database handle.
+func (h *History) Close() error {
+	return h.db.Close()
+}
+
+// StartRun records the start of a new run and returns its ID.
+func (h *History) StartRun(startedAt time.Time) (int64, error) {
+	res, err := h.db.Exec(`INSERT INTO runs (started_at) VALUES (?)`, startedAt.UnixNano())
+	if err != nil {
+		return 0, fmt.Errorf("starting doctor run: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// EndRun marks runID as finished at endedAt.
+func (h *History) EndRun(runID int64, endedAt time.Time) error {
+	_, err := h.db.Exec(`UPDATE runs SET ended_at = ? WHERE id = ?`, endedAt.UnixNano(), runID)
+	if err != nil {
+		return fmt.Errorf("ending doctor run %d: %w", runID, err)
+	}
+	return nil
+}
+
+// RecordResult appends one check's result to runID.
+func (h *History) RecordResult(runID int64, checkName, status, message string) error {
+	_, err := h.db.Exec(`INSERT INTO results (run_id, check_name, status, message) VALUES (?, ?, ?, ?)`,
+		runID, checkName, status, message)
+	if err != nil {
+		return fmt.Errorf("recording result for %s: %w", checkName, err)
+	}
+	return nil
+}
+
+// RecordStaleAttachment appends one stale attachment finding to runID.
+func (h *History) RecordStaleAttachment(runID int64, rig, pinnedBeadID, moleculeID string, lastUpdated time.Time, staleDuration time.Duration) error {
+	var lastUpdatedNS any
+	if !lastUpdated.IsZero() {
+		lastUpdatedNS = lastUpdated.UnixNano()
+	}
+	_, err := h.db.Exec(`INSERT INTO stale_attachments (run_id, rig, pinned_bead_id, molecule_id, last_updated, stale_duration_ns) VALUES (?, ?, ?, ?, ?, ?)`,
+		runID, rig, pinnedBeadID, moleculeID, lastUpdatedNS, staleDuration.Nanoseconds())
+	if err != nil {
+		return fmt.Errorf("recording stale attachment for %s: %w", moleculeID, err)
+	}
+	return nil
+}
+
+// Result is one row from the results table.
+type Result struct {
+	RunID     int64
+	StartedAt time.Time
+	Status    string
+	Message   string
+}
+
+// Recent returns the n most recent results for check, newest first.
+func (h *History) Recent(check string, n int) ([]Result, error) {
+	rows, err := h.db.Query(`
+		SELECT r.id, r.started_at, res.status, res.message
+		FROM results res
+		JOIN runs r ON r.id = res.run_id
+		WHERE res.check_name = ?
+		ORDER BY r.started_at DESC
+		LIMIT ?`, check, n)
+	if err != nil {
+		return nil, fmt.Errorf("querying recent results for %s: %w", check, err)
+	}
+	defer rows.Close()
+
+	var out []Result
+	for rows.Next() {
+		var res Result
+		var startedAtNS int64
+		if err := rows.Scan(&res.RunID, &startedAtNS, &res.Status, &res.Message); err != nil {
+			return nil, err
+		}
+		res.StartedAt = time.Unix(0, startedAtNS)
+		out = append(out, res)
+	}
+	return out, rows.Err()
+}
+
+// FlappingChecks returns the names of checks whose status changed from one
+// run to the next more than threshold times within window.
+func (h *History) FlappingChecks(window time.Duration, threshold int) ([]string, error) {
+	cutoff := time.Now().Add(-window).UnixNano()
+	rows, err := h.db.Query(`
+		SELECT res.check_name, r.started_at, res.status
+		FROM results res
+		JOIN runs r ON r.id = res.run_id
+		WHERE r.started_at >= ?
+		ORDER BY res.check_name, r.started_at ASC`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("querying flapping checks: %w", err)
+	}
+	defer rows.Close()
+
+	transitions := make(map[string]int)
+	lastStatus := make(map[string]string)
+	for rows.Next() {
+		var checkName, status string
+		var startedAtNS int64
+		if err := rows.Scan(&checkName, &startedAtNS, &status); err != nil {
+			return nil, err
+		}
+		if prev, ok := lastStatus[checkName]; ok && prev != status {
+			transitions[checkName]++
+		}
+		lastStatus[checkName] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var flapping []string
+	for checkName, count := range transitions {
+		if count > threshold {
+			flapping = append(flapping, checkName)
+		}
+	}
+	return flapping, nil
+}
+
+// StaleKey identifies a molecule that has repeatedly shown up as stale.
+type StaleKey struct {
+	Rig        string
+	MoleculeID string
+}
+
+// runIDsSince returns the run IDs started within window, newest first.
+func (h *History) runIDsSince(window time.Duration) ([]int64, error) {
+	cutoff := time.Now().Add(-window).UnixNano()
+	rows, err := h.db.Query(`SELECT id FROM runs WHERE started_at >= ? ORDER BY started_at DESC`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("querying runs: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// staleRunsByKey maps each (rig, molecule_id) pair seen in window to the
+// set of run IDs in which it showed up stale.
+func (h *History) staleRunsByKey(window time.Duration) (map[StaleKey]map[int64]bool, error) {
+	cutoff := time.Now().Add(-window).UnixNano()
+	rows, err := h.db.Query(`SELECT run_id, rig, molecule_id FROM stale_attachments WHERE run_id IN (SELECT id FROM runs WHERE started_at >= ?)`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("querying stale attachments: %w", err)
+	}
+	defer rows.Close()
+
+	byKey := make(map[StaleKey]map[int64]bool)
+	for rows.Next() {
+		var runID int64
+		var key StaleKey
+		if err := rows.Scan(&runID, &key.Rig, &key.MoleculeID); err != nil {
+			return nil, err
+		}
+		if byKey[key] == nil {
+			byKey[key] = make(map[int64]bool)
+		}
+		byKey[key][runID] = true
+	}
+	return byKey, rows.Err()
+}
+
+// StaleStreak reports, for a single (rig, molecule) pair, how many of the
+// most recent runs within window it showed up stale in a row (counting
+// back from the most recent run until the streak breaks), and how many
+// runs total fall within window.
+func (h *History) StaleStreak(key StaleKey, window time.Duration) (streak, totalRuns int, err error) {
+	runIDs, err := h.runIDsSince(window)
+	if err != nil {
+		return 0, 0, err
+	}
+	byKey, err := h.staleRunsByKey(window)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	runs := byKey[key]
+	for _, runID := range runIDs {
+		if !runs[runID] {
+			break
+		}
+		streak++
+	}
+	return streak, len(runIDs), nil
+}
+
+// ChronicStale returns the (rig, molecule_id) pairs that appeared stale in
+// at least minConsecutive consecutive runs within window, counting back from
+// the most recent run.
+func (h *History) ChronicStale(window time.Duration, minConsecutive int) ([]StaleKey, error) {
+	runIDs, err := h.runIDsSince(window)
+	if err != nil {
+		return nil, err
+	}
+	byKey, err := h.staleRunsByKey(window)
+	if err != nil {
+		return nil, err
+	}
+
+	var chronic []StaleKey
+	for key, runs := range byKey {
+		streak := 0
+		for _, runID := range runIDs {
+			if !runs[runID] {
+				break
+			}
+			streak++
+		}
+		if streak >= minConsecutive {
+			chronic = append(chronic, key)
+		}
+	}
+	return chronic, nil
+}
+
+// Prune deletes runs (and their results/stale_attachments) older than
+// retention, and Compact should be called afterward to reclaim the space.
+func (h *History) Prune(retention time.Duration) error {
+	cutoff := time.Now().Add(-retention).UnixNano()
+	tx, err := h.db.Begin()
+	if err != nil {
+		return fmt.Errorf("starting prune transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM results WHERE run_id IN (SELECT id FROM runs WHERE started_at < ?)`, cutoff); err != nil {
+		return fmt.Errorf("pruning results: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM stale_attachments WHERE run_id IN (SELECT id FROM runs WHERE started_at < ?)`, cutoff); err != nil {
+		return fmt.Errorf("pruning stale attachments: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM runs WHERE started_at < ?`, cutoff); err != nil {
+		return fmt.Errorf("pruning runs: %w", err)
+	}
+	return tx.Commit()
+}
+
+// Compact reclaims disk space freed by Prune.
+func (h *History) Compact() error {
+	if _, err := h.db.Exec(`VACUUM`); err != nil {
+		return fmt.Errorf("compacting doctor history: %w", err)
+	}
+	return nil
+}
+
+// PruneAndCompact deletes runs older than retention and reclaims the space
+// they freed. This is what "gt doctor history prune" runs.
+func (h *History) PruneAndCompact(retention time.Duration) error {
+	if err := h.Prune(retention); err != nil {
+		return err
+	}
+	return h.Compact()
+}
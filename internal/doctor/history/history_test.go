@@ -0,0 +1,202 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestHistory(t *testing.T) *History {
+	t.Helper()
+	h, err := Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("opening history: %v", err)
+	}
+	t.Cleanup(func() { h.Close() })
+	return h
+}
+
+// recordRun starts and ends a run at the given time, optionally recording a
+// stale_attachments row for key, and returns the run ID.
+func recordRun(t *testing.T, h *History, at time.Time, key *StaleKey) int64 {
+	t.Helper()
+	runID, err := h.StartRun(at)
+	if err != nil {
+		t.Fatalf("starting run: %v", err)
+	}
+	if key != nil {
+		if err := h.RecordStaleAttachment(runID, key.Rig, "bd-1", key.MoleculeID, at, time.Hour); err != nil {
+			t.Fatalf("recording stale attachment: %v", err)
+		}
+	}
+	if err := h.EndRun(runID, at.Add(time.Second)); err != nil {
+		t.Fatalf("ending run: %v", err)
+	}
+	return runID
+}
+
+func TestStaleStreakCountsConsecutiveRunsFromMostRecent(t *testing.T) {
+	h := openTestHistory(t)
+	key := StaleKey{Rig: "rig-a", MoleculeID: "mol-1"}
+	now := time.Now()
+
+	// Three runs, oldest to newest, all stale for key.
+	recordRun(t, h, now.Add(-3*time.Minute), &key)
+	recordRun(t, h, now.Add(-2*time.Minute), &key)
+	recordRun(t, h, now.Add(-1*time.Minute), &key)
+
+	streak, total, err := h.StaleStreak(key, time.Hour)
+	if err != nil {
+		t.Fatalf("StaleStreak: %v", err)
+	}
+	if streak != 3 {
+		t.Errorf("expected streak of 3, got %d", streak)
+	}
+	if total != 3 {
+		t.Errorf("expected 3 total runs, got %d", total)
+	}
+}
+
+func TestStaleStreakBreaksOnGap(t *testing.T) {
+	h := openTestHistory(t)
+	key := StaleKey{Rig: "rig-a", MoleculeID: "mol-1"}
+	now := time.Now()
+
+	// Stale, then clean, then stale again: the streak counts back from the
+	// most recent run and must stop at the clean run in between.
+	recordRun(t, h, now.Add(-3*time.Minute), &key)
+	recordRun(t, h, now.Add(-2*time.Minute), nil)
+	recordRun(t, h, now.Add(-1*time.Minute), &key)
+
+	streak, total, err := h.StaleStreak(key, time.Hour)
+	if err != nil {
+		t.Fatalf("StaleStreak: %v", err)
+	}
+	if streak != 1 {
+		t.Errorf("expected streak of 1 (broken by the clean run), got %d", streak)
+	}
+	if total != 3 {
+		t.Errorf("expected 3 total runs, got %d", total)
+	}
+}
+
+func TestStaleStreakIgnoresRunsOutsideWindow(t *testing.T) {
+	h := openTestHistory(t)
+	key := StaleKey{Rig: "rig-a", MoleculeID: "mol-1"}
+	now := time.Now()
+
+	recordRun(t, h, now.Add(-2*time.Hour), &key) // outside the 1h window
+	recordRun(t, h, now.Add(-1*time.Minute), &key)
+
+	streak, total, err := h.StaleStreak(key, time.Hour)
+	if err != nil {
+		t.Fatalf("StaleStreak: %v", err)
+	}
+	if streak != 1 || total != 1 {
+		t.Errorf("expected streak=1 total=1 (old run excluded by window), got streak=%d total=%d", streak, total)
+	}
+}
+
+func TestChronicStaleRequiresMinConsecutiveRuns(t *testing.T) {
+	h := openTestHistory(t)
+	chronicKey := StaleKey{Rig: "rig-a", MoleculeID: "mol-chronic"}
+	onceKey := StaleKey{Rig: "rig-a", MoleculeID: "mol-once"}
+	now := time.Now()
+
+	// chronicKey is stale in all three runs; onceKey only shows up in the
+	// most recent one. Since ChronicStale counts back from the most recent
+	// run across every run in the window, onceKey's streak must stay at 1.
+	run1, err := h.StartRun(now.Add(-3 * time.Minute))
+	if err != nil {
+		t.Fatalf("starting run: %v", err)
+	}
+	mustRecordStale(t, h, run1, chronicKey)
+	mustEndRun(t, h, run1, now.Add(-3*time.Minute+time.Second))
+
+	run2, err := h.StartRun(now.Add(-2 * time.Minute))
+	if err != nil {
+		t.Fatalf("starting run: %v", err)
+	}
+	mustRecordStale(t, h, run2, chronicKey)
+	mustEndRun(t, h, run2, now.Add(-2*time.Minute+time.Second))
+
+	run3, err := h.StartRun(now.Add(-1 * time.Minute))
+	if err != nil {
+		t.Fatalf("starting run: %v", err)
+	}
+	mustRecordStale(t, h, run3, chronicKey)
+	mustRecordStale(t, h, run3, onceKey)
+	mustEndRun(t, h, run3, now.Add(-1*time.Minute+time.Second))
+
+	chronic, err := h.ChronicStale(time.Hour, 3)
+	if err != nil {
+		t.Fatalf("ChronicStale: %v", err)
+	}
+	if len(chronic) != 1 || chronic[0] != chronicKey {
+		t.Errorf("expected only %+v to be chronic, got %+v", chronicKey, chronic)
+	}
+}
+
+func mustRecordStale(t *testing.T, h *History, runID int64, key StaleKey) {
+	t.Helper()
+	if err := h.RecordStaleAttachment(runID, key.Rig, "bd-1", key.MoleculeID, time.Now(), time.Hour); err != nil {
+		t.Fatalf("recording stale attachment: %v", err)
+	}
+}
+
+func mustEndRun(t *testing.T, h *History, runID int64, at time.Time) {
+	t.Helper()
+	if err := h.EndRun(runID, at); err != nil {
+		t.Fatalf("ending run: %v", err)
+	}
+}
+
+func TestFlappingChecksCountsStatusTransitions(t *testing.T) {
+	h := openTestHistory(t)
+	now := time.Now()
+
+	statuses := []string{"ok", "warning", "ok", "warning", "ok"}
+	for i, status := range statuses {
+		runID, err := h.StartRun(now.Add(time.Duration(i-len(statuses)) * time.Minute))
+		if err != nil {
+			t.Fatalf("starting run: %v", err)
+		}
+		if err := h.RecordResult(runID, "flaky-check", status, "msg"); err != nil {
+			t.Fatalf("recording result: %v", err)
+		}
+		if err := h.RecordResult(runID, "stable-check", "ok", "msg"); err != nil {
+			t.Fatalf("recording result: %v", err)
+		}
+	}
+
+	flapping, err := h.FlappingChecks(time.Hour, 2)
+	if err != nil {
+		t.Fatalf("FlappingChecks: %v", err)
+	}
+	if len(flapping) != 1 || flapping[0] != "flaky-check" {
+		t.Errorf("expected only flaky-check to flap above threshold 2, got %v", flapping)
+	}
+}
+
+func TestPruneAndCompactRemovesOldRuns(t *testing.T) {
+	h := openTestHistory(t)
+	key := StaleKey{Rig: "rig-a", MoleculeID: "mol-1"}
+	now := time.Now()
+
+	oldRunID := recordRun(t, h, now.Add(-48*time.Hour), &key)
+	recordRun(t, h, now.Add(-1*time.Minute), &key)
+
+	if err := h.PruneAndCompact(24 * time.Hour); err != nil {
+		t.Fatalf("PruneAndCompact: %v", err)
+	}
+
+	ids, err := h.runIDsSince(72 * time.Hour)
+	if err != nil {
+		t.Fatalf("runIDsSince: %v", err)
+	}
+	for _, id := range ids {
+		if id == oldRunID {
+			t.Errorf("expected run %d to be pruned from runs table, but it's still present", oldRunID)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doctor/history"
+)
+
+// HistoryPath is the default location of the doctor run history database,
+// relative to the town root.
+const HistoryPath = ".gastown/doctor-history.db"
+
+// DefaultChronicWindow and DefaultChronicMinRuns bound what counts as a
+// "chronic" stale attachment: stale in at least this many consecutive runs
+// in this window, counting back from the most recent run.
+const (
+	DefaultChronicWindow  = 24 * time.Hour
+	DefaultChronicMinRuns = 3
+)
+
+// RecordRun persists one batch of CheckResults - typically everything a
+// single Runner.Run call returned - to h as a single run.
+func RecordRun(h *history.History, startedAt, endedAt time.Time, results []*CheckResult) (int64, error) {
+	runID, err := h.StartRun(startedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, r := range results {
+		if err := h.RecordResult(runID, r.Name, string(r.Status), r.Message); err != nil {
+			return runID, err
+		}
+		if stale, ok := r.Data.([]StaleAttachment); ok {
+			for _, sa := range stale {
+				if err := h.RecordStaleAttachment(runID, sa.Rig, sa.PinnedBeadID, sa.MoleculeID, sa.LastUpdated, sa.StaleDuration); err != nil {
+					return runID, err
+				}
+			}
+		}
+	}
+
+	if err := h.EndRun(runID, endedAt); err != nil {
+		return runID, err
+	}
+	return runID, nil
+}
+
+// UpgradeChronicStale inspects h for stale attachments in result that have
+// been flagged in at least minConsecutive consecutive runs within window,
+// counting back from the most recent run, and
+// upgrades result from StatusWarning to StatusError when it finds any,
+// appending a "chronic: ... stale in N of last M runs" detail per chronic
+// molecule.
+func UpgradeChronicStale(h *history.History, result *CheckResult, window time.Duration, minConsecutive int) error {
+	if result.Status != StatusWarning {
+		return nil
+	}
+	stale, ok := result.Data.([]StaleAttachment)
+	if !ok {
+		return nil
+	}
+
+	var anyChronic bool
+	for _, sa := range stale {
+		key := history.StaleKey{Rig: sa.Rig, MoleculeID: sa.MoleculeID}
+		streak, totalRuns, err := h.StaleStreak(key, window)
+		if err != nil {
+			return fmt.Errorf("checking chronic stale for %s: %w", sa.MoleculeID, err)
+		}
+		if streak < minConsecutive {
+			continue
+		}
+		anyChronic = true
+		result.Details = append(result.Details, fmt.Sprintf("chronic: %s stale in %d of last %d runs", sa.MoleculeID, streak, totalRuns))
+	}
+
+	if anyChronic {
+		result.Status = StatusError
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyPath is the default location of the remediation policy file,
+// relative to the town root.
+const PolicyPath = ".gastown/doctor-policies.yaml"
+
+// Mode controls whether a Remediator may actually act on a check's
+// remediations.
+type Mode string
+
+const (
+	// ModeOff never runs remediations, even to log what it would have done.
+	ModeOff Mode = "off"
+	// ModeDryRun logs what would be remediated without touching anything.
+	ModeDryRun Mode = "dry-run"
+	// ModeAuto runs remediations against the beads client.
+	ModeAuto Mode = "auto"
+)
+
+// ActionPolicy bounds how often and how many times a single action may fire.
+type ActionPolicy struct {
+	// RateLimit is the minimum time between two attempts of this action
+	// against the same molecule.
+	RateLimit time.Duration `yaml:"rate_limit"`
+	// Backoff is multiplied into RateLimit once for each attempt after the
+	// first (values <= 1 are ignored), so repeated failures back off rather
+	// than re-firing on every run.
+	Backoff float64 `yaml:"backoff"`
+	// MaxAttempts is how many times this action may fire for a given
+	// molecule before the Remediator gives up and only reports.
+	MaxAttempts int `yaml:"max_attempts"`
+	// EscalateAfter is how long to wait after the most recent attempt of this
+	// action before the next request for it escalates to a more severe one
+	// (e.g. nudge-polecat -> kill-polecat).
+	EscalateAfter time.Duration `yaml:"escalate_after"`
+}
+
+// QuietHours is a daily window, in "HH:MM" 24-hour local time, during which
+// a Remediator should not act even in auto mode.
+type QuietHours struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// CheckPolicy is the remediation policy for a single check, keyed by check
+// name in the policy file.
+type CheckPolicy struct {
+	Mode       Mode                               `yaml:"mode"`
+	Actions    map[RemediationAction]ActionPolicy `yaml:"actions"`
+	QuietHours []QuietHours                       `yaml:"quiet_hours"`
+}
+
+// Policies maps check name to its CheckPolicy.
+type Policies map[string]CheckPolicy
+
+// LoadPolicies reads and parses the remediation policy file at path. A
+// missing file is not an error: it is treated as an empty policy set, which
+// leaves every check in its zero-value mode (ModeOff).
+func LoadPolicies(path string) (Policies, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Policies{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading doctor policies: %w", err)
+	}
+
+	var p Policies
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing doctor policies %s: %w", path, err)
+	}
+	if p == nil {
+		p = Policies{}
+	}
+	return p, nil
+}
+
+// inQuietHours reports whether t falls in any of the policy's quiet windows.
+func (cp CheckPolicy) inQuietHours(t time.Time) bool {
+	for _, qh := range cp.QuietHours {
+		start, err := time.Parse("15:04", qh.Start)
+		if err != nil {
+			continue
+		}
+		end, err := time.Parse("15:04", qh.End)
+		if err != nil {
+			continue
+		}
+		minutes := t.Hour()*60 + t.Minute()
+		startMinutes := start.Hour()*60 + start.Minute()
+		endMinutes := end.Hour()*60 + end.Minute()
+		if startMinutes <= endMinutes {
+			if minutes >= startMinutes && minutes < endMinutes {
+				return true
+			}
+		} else {
+			// Window wraps past midnight.
+			if minutes >= startMinutes || minutes < endMinutes {
+				return true
+			}
+		}
+	}
+	return false
+}
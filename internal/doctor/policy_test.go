@@ -0,0 +1,99 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadPoliciesParsesYAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doctor-policies.yaml")
+	writeFile(t, path, `
+stale-attachments:
+  mode: auto
+  actions:
+    nudge-polecat:
+      rate_limit: 10m
+      backoff: 2
+      max_attempts: 5
+      escalate_after: 1h
+  quiet_hours:
+    - start: "22:00"
+      end: "06:00"
+`)
+
+	policies, err := LoadPolicies(path)
+	if err != nil {
+		t.Fatalf("LoadPolicies: %v", err)
+	}
+
+	cp, ok := policies["stale-attachments"]
+	if !ok {
+		t.Fatalf("expected a policy for stale-attachments, got %v", policies)
+	}
+	if cp.Mode != ModeAuto {
+		t.Errorf("expected mode auto, got %q", cp.Mode)
+	}
+	ap, ok := cp.Actions[ActionNudgePolecat]
+	if !ok {
+		t.Fatalf("expected nudge-polecat action policy, got %v", cp.Actions)
+	}
+	if ap.RateLimit != 10*time.Minute || ap.MaxAttempts != 5 || ap.EscalateAfter != time.Hour {
+		t.Errorf("unexpected action policy: %+v", ap)
+	}
+	if len(cp.QuietHours) != 1 || cp.QuietHours[0].Start != "22:00" || cp.QuietHours[0].End != "06:00" {
+		t.Errorf("unexpected quiet hours: %+v", cp.QuietHours)
+	}
+}
+
+func TestLoadPoliciesMissingFileIsEmpty(t *testing.T) {
+	policies, err := LoadPolicies(filepath.Join(t.TempDir(), "does-not-exist.yaml"))
+	if err != nil {
+		t.Fatalf("expected no error for missing file, got %v", err)
+	}
+	if len(policies) != 0 {
+		t.Errorf("expected empty policies, got %v", policies)
+	}
+}
+
+func TestInQuietHoursWithinSameDayWindow(t *testing.T) {
+	cp := CheckPolicy{QuietHours: []QuietHours{{Start: "09:00", End: "17:00"}}}
+
+	inside := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !cp.inQuietHours(inside) {
+		t.Errorf("expected %v to be in quiet hours", inside)
+	}
+
+	outside := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	if cp.inQuietHours(outside) {
+		t.Errorf("expected %v to be outside quiet hours", outside)
+	}
+}
+
+func TestInQuietHoursWraparoundPastMidnight(t *testing.T) {
+	cp := CheckPolicy{QuietHours: []QuietHours{{Start: "22:00", End: "06:00"}}}
+
+	lateNight := time.Date(2026, 1, 1, 23, 30, 0, 0, time.UTC)
+	if !cp.inQuietHours(lateNight) {
+		t.Errorf("expected %v (late night) to be in wraparound quiet hours", lateNight)
+	}
+
+	earlyMorning := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	if !cp.inQuietHours(earlyMorning) {
+		t.Errorf("expected %v (early morning) to be in wraparound quiet hours", earlyMorning)
+	}
+
+	midday := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if cp.inQuietHours(midday) {
+		t.Errorf("expected %v to be outside wraparound quiet hours", midday)
+	}
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
@@ -0,0 +1,88 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// TerminalProgressReporter prints a line per progress event, for a
+// human watching "gt doctor" run.
+type TerminalProgressReporter struct {
+	Out io.Writer
+
+	mu sync.Mutex
+}
+
+func (t *TerminalProgressReporter) CheckStarted(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, "-> %s...\n", name)
+}
+
+func (t *TerminalProgressReporter) CheckPartial(name string, partial *CheckResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, "   %s: %s\n", name, partial.Message)
+}
+
+func (t *TerminalProgressReporter) CheckFinished(name string, result *CheckResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.Out, "%s %s: %s\n", statusSymbol(result.Status), name, result.Message)
+}
+
+func statusSymbol(s Status) string {
+	switch s {
+	case StatusOK:
+		return "[ok]"
+	case StatusWarning:
+		return "[warn]"
+	case StatusError:
+		return "[err]"
+	default:
+		return "[?]"
+	}
+}
+
+// JSONProgressReporter writes one JSON event per line (NDJSON), suitable
+// for a caller that wants to render its own progress UI.
+type JSONProgressReporter struct {
+	Out io.Writer
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+type progressEvent struct {
+	Type    string       `json:"type"` // "started", "partial", or "finished"
+	Check   string       `json:"check"`
+	Result  *CheckResult `json:"result,omitempty"`
+	Partial *CheckResult `json:"partial,omitempty"`
+}
+
+func (j *JSONProgressReporter) encoder() *json.Encoder {
+	if j.enc == nil {
+		j.enc = json.NewEncoder(j.Out)
+	}
+	return j.enc
+}
+
+func (j *JSONProgressReporter) CheckStarted(name string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.encoder().Encode(progressEvent{Type: "started", Check: name})
+}
+
+func (j *JSONProgressReporter) CheckPartial(name string, partial *CheckResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.encoder().Encode(progressEvent{Type: "partial", Check: name, Partial: partial})
+}
+
+func (j *JSONProgressReporter) CheckFinished(name string, result *CheckResult) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.encoder().Encode(progressEvent{Type: "finished", Check: name, Result: result})
+}
@@ -0,0 +1,32 @@
+package doctor
+
+// RemediationAction identifies an automated response a Remediator can take
+// against a stuck polecat or molecule.
+type RemediationAction string
+
+const (
+	// ActionNudgePolecat asks a polecat to check in without killing it.
+	ActionNudgePolecat RemediationAction = "nudge-polecat"
+	// ActionKillPolecat terminates a polecat that appears stuck.
+	ActionKillPolecat RemediationAction = "kill-polecat"
+	// ActionReassignMolecule moves an in-progress molecule to a different assignee.
+	ActionReassignMolecule RemediationAction = "reassign-molecule"
+	// ActionDetachAttachment unpins a bead whose attached molecule is gone or broken.
+	ActionDetachAttachment RemediationAction = "detach-attachment"
+)
+
+// Remediation is a single automated action a Check would like the Remediator
+// to consider taking in response to a finding.
+type Remediation struct {
+	Action       RemediationAction
+	WorkDir      string // directory containing the .beads dir this remediation applies to
+	Rig          string
+	PinnedBeadID string
+	MoleculeID   string
+	// Worker identifies the polecat or crew worker this remediation targets
+	// (e.g. for ActionKillPolecat, which has no MoleculeID of its own). Used
+	// alongside MoleculeID to key the remediation journal, so two different
+	// workers never collide under the same journal entry.
+	Worker string
+	Reason string
+}
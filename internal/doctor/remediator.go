@@ -0,0 +1,186 @@
+package doctor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Remediator consumes CheckResults and, per a per-check Policy, invokes
+// remediation actions against the beads client - nudging or killing stuck
+// polecats, reassigning molecules, or detaching broken attachments.
+type Remediator struct {
+	Policies Policies
+
+	mu      sync.Mutex
+	journal map[string]*journalEntry // key: checkName + "/" + MoleculeID + "/" + Worker + "/" + Action
+}
+
+// journalEntry tracks how many times, and how recently, an action has fired
+// for a given molecule (or worker, for remediations with no molecule) so
+// repeated triggers respect backoff and max-attempts.
+type journalEntry struct {
+	Attempts    int
+	LastAttempt time.Time
+}
+
+// NewRemediator creates a Remediator that enforces policies.
+func NewRemediator(policies Policies) *Remediator {
+	return &Remediator{
+		Policies: policies,
+		journal:  make(map[string]*journalEntry),
+	}
+}
+
+// Outcome describes what the Remediator did, or decided not to do, for a
+// single Remediation.
+type Outcome struct {
+	Remediation Remediation
+	Action      RemediationAction // may differ from Remediation.Action if escalated
+	Ran         bool
+	Message     string
+	Err         error
+}
+
+// Process applies policy to every remediation attached to result and
+// returns what happened for each. checkName selects the policy to apply.
+func (r *Remediator) Process(checkName string, result *CheckResult) []Outcome {
+	policy := r.Policies[checkName]
+	if policy.Mode == "" {
+		policy.Mode = ModeOff
+	}
+
+	now := time.Now()
+	var outcomes []Outcome
+	for _, rem := range result.Remediations {
+		outcomes = append(outcomes, r.processOne(checkName, policy, rem, now))
+	}
+	return outcomes
+}
+
+func (r *Remediator) processOne(checkName string, policy CheckPolicy, rem Remediation, now time.Time) Outcome {
+	if policy.Mode == ModeOff {
+		return Outcome{Remediation: rem, Action: rem.Action, Message: "remediation disabled by policy"}
+	}
+
+	action, entry, ap, configured := r.resolveAction(checkName, policy, rem, now)
+	if !configured {
+		// An action with no entry in the policy's Actions map gets the zero
+		// ActionPolicy{} - no rate limit, no cap - which would otherwise
+		// fire unbounded on every run. Treat "not configured" as "not
+		// allowed" rather than "no limits".
+		return Outcome{Remediation: rem, Action: action, Message: "action not configured in policy, skipping"}
+	}
+
+	if ap.MaxAttempts > 0 && entry.Attempts >= ap.MaxAttempts {
+		return Outcome{
+			Remediation: rem,
+			Action:      action,
+			Message:     fmt.Sprintf("already remediated %d times, giving up", entry.Attempts),
+		}
+	}
+
+	if !entry.LastAttempt.IsZero() {
+		wait := ap.RateLimit
+		if ap.Backoff > 1 {
+			for i := 0; i < entry.Attempts-1; i++ {
+				wait = time.Duration(float64(wait) * ap.Backoff)
+			}
+		}
+		if now.Sub(entry.LastAttempt) < wait {
+			return Outcome{Remediation: rem, Action: action, Message: "within backoff window, skipping"}
+		}
+	}
+
+	if policy.inQuietHours(now) {
+		return Outcome{Remediation: rem, Action: action, Message: "quiet hours, skipping"}
+	}
+
+	if policy.Mode == ModeDryRun {
+		r.record(checkName, rem, action, now)
+		return Outcome{Remediation: rem, Action: action, Ran: false, Message: "dry-run: would have " + string(action)}
+	}
+
+	err := r.invoke(rem, action)
+	r.record(checkName, rem, action, now)
+	if err != nil {
+		return Outcome{Remediation: rem, Action: action, Ran: false, Err: err, Message: err.Error()}
+	}
+	return Outcome{Remediation: rem, Action: action, Ran: true, Message: "ran " + string(action)}
+}
+
+// resolveAction escalates a nudge into a kill once the nudge has been
+// outstanding for longer than its policy's EscalateAfter window, and returns
+// the journal entry and ActionPolicy that apply to the (possibly escalated)
+// action, along with whether that action actually has an entry in the
+// policy's Actions map.
+func (r *Remediator) resolveAction(checkName string, policy CheckPolicy, rem Remediation, now time.Time) (action RemediationAction, entry *journalEntry, ap ActionPolicy, configured bool) {
+	action = rem.Action
+	if action == ActionNudgePolecat {
+		nudgeAP, nudgeConfigured := policy.Actions[ActionNudgePolecat]
+		nudgeEntry := r.lookup(checkName, rem.MoleculeID, rem.Worker, ActionNudgePolecat)
+		if nudgeConfigured && nudgeAP.EscalateAfter > 0 && nudgeEntry.Attempts > 0 && now.Sub(nudgeEntry.LastAttempt) >= nudgeAP.EscalateAfter {
+			action = ActionKillPolecat
+		}
+	}
+	ap, configured = policy.Actions[action]
+	return action, r.lookup(checkName, rem.MoleculeID, rem.Worker, action), ap, configured
+}
+
+func (r *Remediator) lookup(checkName, moleculeID, worker string, action RemediationAction) *journalEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := journalKey(checkName, moleculeID, worker, action)
+	entry, ok := r.journal[key]
+	if !ok {
+		entry = &journalEntry{}
+	}
+	return entry
+}
+
+func (r *Remediator) record(checkName string, rem Remediation, action RemediationAction, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := journalKey(checkName, rem.MoleculeID, rem.Worker, action)
+	entry, ok := r.journal[key]
+	if !ok {
+		entry = &journalEntry{}
+		r.journal[key] = entry
+	}
+	entry.Attempts++
+	entry.LastAttempt = now
+}
+
+// journalKey identifies what an action has previously fired against.
+// Including both moleculeID and worker keeps two different dead polecats (or
+// a polecat and its molecule) from colliding on the same journal entry even
+// when one of the two is empty.
+func journalKey(checkName, moleculeID, worker string, action RemediationAction) string {
+	return checkName + "/" + moleculeID + "/" + worker + "/" + string(action)
+}
+
+// invoke performs the actual remediation against the beads client that owns
+// rem.WorkDir.
+func (r *Remediator) invoke(rem Remediation, action RemediationAction) error {
+	bd := beads.New(rem.WorkDir)
+	switch action {
+	case ActionNudgePolecat:
+		return bd.NudgePolecat(rem.MoleculeID)
+	case ActionKillPolecat:
+		// A dead polecat carries no MoleculeID - fall back to its Worker name
+		// so we still pass KillPolecat something that identifies it.
+		target := rem.MoleculeID
+		if target == "" {
+			target = rem.Worker
+		}
+		return bd.KillPolecat(target)
+	case ActionReassignMolecule:
+		return bd.ReassignMolecule(rem.MoleculeID, "")
+	case ActionDetachAttachment:
+		return bd.DetachAttachment(rem.PinnedBeadID)
+	default:
+		return fmt.Errorf("unknown remediation action %q", action)
+	}
+}
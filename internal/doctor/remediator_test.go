@@ -0,0 +1,129 @@
+package doctor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProcessOneSkipsUnconfiguredActionUnderAutoMode(t *testing.T) {
+	r := NewRemediator(Policies{
+		"stale-attachments": {
+			Mode: ModeAuto,
+			// No entry for nudge-polecat: this must NOT run unbounded.
+			Actions: map[RemediationAction]ActionPolicy{},
+		},
+	})
+
+	rem := Remediation{Action: ActionNudgePolecat, MoleculeID: "mol-1", WorkDir: t.TempDir()}
+	outcomes := r.Process("stale-attachments", &CheckResult{Remediations: []Remediation{rem}})
+
+	if len(outcomes) != 1 {
+		t.Fatalf("expected 1 outcome, got %d", len(outcomes))
+	}
+	if outcomes[0].Ran {
+		t.Errorf("expected unconfigured action to not run, got %+v", outcomes[0])
+	}
+}
+
+func TestProcessOneRespectsMaxAttempts(t *testing.T) {
+	r := NewRemediator(Policies{
+		"stale-attachments": {
+			Mode: ModeAuto,
+			Actions: map[RemediationAction]ActionPolicy{
+				ActionNudgePolecat: {MaxAttempts: 1},
+			},
+		},
+	})
+
+	rem := Remediation{Action: ActionNudgePolecat, MoleculeID: "mol-1", WorkDir: t.TempDir()}
+	result := &CheckResult{Remediations: []Remediation{rem}}
+
+	first := r.Process("stale-attachments", result)
+	if !first[0].Ran {
+		t.Fatalf("expected first attempt to run, got %+v", first[0])
+	}
+
+	second := r.Process("stale-attachments", result)
+	if second[0].Ran {
+		t.Errorf("expected second attempt to be blocked by max_attempts, got %+v", second[0])
+	}
+}
+
+func TestProcessOneRespectsRateLimit(t *testing.T) {
+	r := NewRemediator(Policies{
+		"stale-attachments": {
+			Mode: ModeAuto,
+			Actions: map[RemediationAction]ActionPolicy{
+				ActionNudgePolecat: {RateLimit: time.Hour, MaxAttempts: 10},
+			},
+		},
+	})
+
+	rem := Remediation{Action: ActionNudgePolecat, MoleculeID: "mol-1", WorkDir: t.TempDir()}
+	result := &CheckResult{Remediations: []Remediation{rem}}
+
+	first := r.Process("stale-attachments", result)
+	if !first[0].Ran {
+		t.Fatalf("expected first attempt to run, got %+v", first[0])
+	}
+
+	second := r.Process("stale-attachments", result)
+	if second[0].Ran {
+		t.Errorf("expected second attempt within rate limit window to be skipped, got %+v", second[0])
+	}
+}
+
+func TestProcessOneDryRunNeverInvokesAction(t *testing.T) {
+	r := NewRemediator(Policies{
+		"stale-attachments": {
+			Mode: ModeDryRun,
+			Actions: map[RemediationAction]ActionPolicy{
+				ActionNudgePolecat: {MaxAttempts: 10},
+			},
+		},
+	})
+
+	rem := Remediation{Action: ActionNudgePolecat, MoleculeID: "mol-1", WorkDir: t.TempDir()}
+	outcomes := r.Process("stale-attachments", &CheckResult{Remediations: []Remediation{rem}})
+
+	if outcomes[0].Ran {
+		t.Errorf("expected dry-run to never mark Ran=true, got %+v", outcomes[0])
+	}
+}
+
+func TestProcessOneTracksDistinctWorkersIndependently(t *testing.T) {
+	r := NewRemediator(Policies{
+		"dead-polecats": {
+			Mode: ModeAuto,
+			Actions: map[RemediationAction]ActionPolicy{
+				ActionKillPolecat: {MaxAttempts: 1},
+			},
+		},
+	})
+
+	// Dead-polecat remediations carry no MoleculeID; only Worker tells two
+	// unrelated polecats apart in the journal.
+	alice := Remediation{Action: ActionKillPolecat, Worker: "alice", WorkDir: t.TempDir()}
+	bob := Remediation{Action: ActionKillPolecat, Worker: "bob", WorkDir: t.TempDir()}
+
+	aliceOut := r.Process("dead-polecats", &CheckResult{Remediations: []Remediation{alice}})
+	if !aliceOut[0].Ran {
+		t.Fatalf("expected alice's remediation to run, got %+v", aliceOut[0])
+	}
+
+	bobOut := r.Process("dead-polecats", &CheckResult{Remediations: []Remediation{bob}})
+	if !bobOut[0].Ran {
+		t.Errorf("expected bob's remediation to run independently of alice's, got %+v", bobOut[0])
+	}
+}
+
+func TestProcessOneOffModeSkipsEverything(t *testing.T) {
+	r := NewRemediator(Policies{})
+
+	rem := Remediation{Action: ActionNudgePolecat, MoleculeID: "mol-1", WorkDir: t.TempDir()}
+	outcomes := r.Process("stale-attachments", &CheckResult{Remediations: []Remediation{rem}})
+
+	if outcomes[0].Ran {
+		t.Errorf("expected an unpolicied check to stay in off mode, got %+v", outcomes[0])
+	}
+}
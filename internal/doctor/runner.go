@@ -0,0 +1,133 @@
+package doctor
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doctor/history"
+)
+
+// ParallelCheck is implemented by checks that can shard their own work
+// across a worker pool and stream partial findings as they go. Checks that
+// don't implement it just run via Check.Run on one of the Runner's workers.
+type ParallelCheck interface {
+	Check
+	RunParallel(ctx context.Context, checkCtx *CheckContext, jobs int, emit func(partial *CheckResult)) *CheckResult
+}
+
+// ProgressReporter receives progress events as a Runner executes checks.
+type ProgressReporter interface {
+	CheckStarted(name string)
+	CheckPartial(name string, partial *CheckResult)
+	CheckFinished(name string, result *CheckResult)
+}
+
+// NoopProgressReporter discards every event. It is the Runner's default.
+type NoopProgressReporter struct{}
+
+func (NoopProgressReporter) CheckStarted(name string)                       {}
+func (NoopProgressReporter) CheckPartial(name string, partial *CheckResult) {}
+func (NoopProgressReporter) CheckFinished(name string, result *CheckResult) {}
+
+// Runner fans a set of checks, and each parallel-capable check's internal
+// work, across a bounded worker pool sized from GOMAXPROCS or a caller-set
+// job count.
+type Runner struct {
+	Checks   []Check
+	Jobs     int // worker pool size; <= 0 means runtime.GOMAXPROCS(0)
+	Progress ProgressReporter
+
+	// History, when set, records every run to persistent storage and
+	// upgrades stale-attachment findings that have recurred across enough
+	// prior runs from StatusWarning to StatusError. ChronicWindow and
+	// ChronicMinRuns default to DefaultChronicWindow/DefaultChronicMinRuns
+	// when left zero.
+	History        *history.History
+	ChronicWindow  time.Duration
+	ChronicMinRuns int
+}
+
+// NewRunner creates a Runner that executes checks with jobs workers (or
+// GOMAXPROCS(0) workers, if jobs <= 0).
+func NewRunner(checks []Check, jobs int) *Runner {
+	return &Runner{Checks: checks, Jobs: jobs}
+}
+
+func (r *Runner) jobs() int {
+	if r.Jobs > 0 {
+		return r.Jobs
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (r *Runner) progress() ProgressReporter {
+	if r.Progress != nil {
+		return r.Progress
+	}
+	return NoopProgressReporter{}
+}
+
+func (r *Runner) chronicWindow() time.Duration {
+	if r.ChronicWindow > 0 {
+		return r.ChronicWindow
+	}
+	return DefaultChronicWindow
+}
+
+func (r *Runner) chronicMinRuns() int {
+	if r.ChronicMinRuns > 0 {
+		return r.ChronicMinRuns
+	}
+	return DefaultChronicMinRuns
+}
+
+// Run executes every check, bounded by the worker pool, and returns their
+// results in the same order Checks was given, regardless of which check
+// finishes first. ctx is passed only to checks that implement
+// ParallelCheck; checks run through Check.Run never see it, and the Runner
+// does not interrupt a check that is already running when ctx is done.
+func (r *Runner) Run(ctx context.Context, checkCtx *CheckContext) []*CheckResult {
+	startedAt := time.Now()
+	results := make([]*CheckResult, len(r.Checks))
+	sem := make(chan struct{}, r.jobs())
+	var wg sync.WaitGroup
+	progress := r.progress()
+
+	for i, check := range r.Checks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, check Check) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			progress.CheckStarted(check.Name())
+
+			var result *CheckResult
+			if pc, ok := check.(ParallelCheck); ok {
+				result = pc.RunParallel(ctx, checkCtx, r.jobs(), func(partial *CheckResult) {
+					progress.CheckPartial(check.Name(), partial)
+				})
+			} else {
+				result = check.Run(checkCtx)
+			}
+
+			progress.CheckFinished(check.Name(), result)
+			results[i] = result
+		}(i, check)
+	}
+
+	wg.Wait()
+
+	// History is best-effort: a lookup or persistence failure shouldn't take
+	// down a doctor run that otherwise completed successfully.
+	if r.History != nil {
+		for _, result := range results {
+			_ = UpgradeChronicStale(r.History, result, r.chronicWindow(), r.chronicMinRuns())
+		}
+		_, _ = RecordRun(r.History, startedAt, time.Now(), results)
+	}
+
+	return results
+}
@@ -0,0 +1,220 @@
+package doctor
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doctor/history"
+)
+
+// fakeCheck is a Check whose Run sleeps for a fixed duration before
+// returning, so tests can control which checks finish first.
+type fakeCheck struct {
+	BaseCheck
+	delay time.Duration
+}
+
+func (f *fakeCheck) Run(ctx *CheckContext) *CheckResult {
+	time.Sleep(f.delay)
+	return &CheckResult{Name: f.Name(), Status: StatusOK, Message: f.Name() + " done"}
+}
+
+// fakeParallelCheck additionally emits a partial result partway through its
+// delay, so tests can observe that a concurrently running fast check
+// finishes without waiting on a slow check.
+type fakeParallelCheck struct {
+	fakeCheck
+	partialAt time.Duration
+}
+
+func (f *fakeParallelCheck) RunParallel(ctx context.Context, checkCtx *CheckContext, jobs int, emit func(partial *CheckResult)) *CheckResult {
+	if f.partialAt > 0 {
+		time.Sleep(f.partialAt)
+		emit(&CheckResult{Name: f.Name(), Status: StatusOK, Message: "partial"})
+	}
+	time.Sleep(f.delay - f.partialAt)
+	return &CheckResult{Name: f.Name(), Status: StatusOK, Message: f.Name() + " done"}
+}
+
+func newFakeCheck(name string, delay time.Duration) *fakeCheck {
+	return &fakeCheck{BaseCheck: BaseCheck{CheckName: name}, delay: delay}
+}
+
+// TestRunnerPreservesOrderRegardlessOfCompletionOrder runs a slow check
+// ahead of a fast one and verifies the result slice still matches the
+// order Checks was given, not completion order.
+func TestRunnerPreservesOrderRegardlessOfCompletionOrder(t *testing.T) {
+	slow := newFakeCheck("slow", 30*time.Millisecond)
+	fast := newFakeCheck("fast", time.Millisecond)
+
+	r := NewRunner([]Check{slow, fast}, 2)
+	results := r.Run(context.Background(), &CheckContext{TownRoot: t.TempDir()})
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Name != "slow" || results[1].Name != "fast" {
+		t.Fatalf("expected results in input order [slow, fast], got [%s, %s]", results[0].Name, results[1].Name)
+	}
+}
+
+// TestRunnerSlowCheckDoesNotBlockFastCheck runs a slow parallel check
+// alongside a fast one and verifies the fast check's finish event is
+// observed before the slow check's partial, proving the pool doesn't
+// serialize them. Run with -race to catch any shared-state bugs in the
+// progress callback path.
+func TestRunnerSlowCheckDoesNotBlockFastCheck(t *testing.T) {
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	slow := &fakeParallelCheck{fakeCheck: fakeCheck{BaseCheck: BaseCheck{CheckName: "slow"}, delay: 40 * time.Millisecond}, partialAt: 30 * time.Millisecond}
+	fast := &fakeParallelCheck{fakeCheck: fakeCheck{BaseCheck: BaseCheck{CheckName: "fast"}, delay: 5 * time.Millisecond}}
+
+	r := &Runner{
+		Checks: []Check{slow, fast},
+		Jobs:   2,
+		Progress: recordingProgressReporter{
+			onPartial:  func(name string, _ *CheckResult) { record(name + ":partial") },
+			onFinished: func(name string, _ *CheckResult) { record(name + ":finished") },
+		},
+	}
+
+	r.Run(context.Background(), &CheckContext{TownRoot: t.TempDir()})
+
+	fastIdx, slowPartialIdx := -1, -1
+	for i, e := range events {
+		if e == "fast:finished" && fastIdx == -1 {
+			fastIdx = i
+		}
+		if e == "slow:partial" && slowPartialIdx == -1 {
+			slowPartialIdx = i
+		}
+	}
+	if fastIdx == -1 || slowPartialIdx == -1 {
+		t.Fatalf("expected both fast:finished and slow:partial events, got %v", events)
+	}
+	if fastIdx > slowPartialIdx {
+		t.Fatalf("fast check finished after slow check's partial, pool may be serializing work: %v", events)
+	}
+}
+
+// recordingProgressReporter is a minimal ProgressReporter for tests.
+type recordingProgressReporter struct {
+	onPartial  func(name string, partial *CheckResult)
+	onFinished func(name string, result *CheckResult)
+}
+
+func (r recordingProgressReporter) CheckStarted(name string) {}
+func (r recordingProgressReporter) CheckPartial(name string, partial *CheckResult) {
+	if r.onPartial != nil {
+		r.onPartial(name, partial)
+	}
+}
+func (r recordingProgressReporter) CheckFinished(name string, result *CheckResult) {
+	if r.onFinished != nil {
+		r.onFinished(name, result)
+	}
+}
+
+// staleResultCheck always reports the same stale attachment as a warning,
+// so tests can drive Runner.Run's History wiring deterministically.
+type staleResultCheck struct {
+	BaseCheck
+	attachment StaleAttachment
+}
+
+func (c *staleResultCheck) Run(ctx *CheckContext) *CheckResult {
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: "1 stale attachment",
+		Data:    []StaleAttachment{c.attachment},
+	}
+}
+
+// TestRunnerRecordsHistoryAndUpgradesChronicStale runs the same
+// stale-attachments finding through a Runner with History set four times in
+// a row (ChronicMinRuns=3) and verifies both halves of the wiring: each run
+// is persisted (StaleStreak sees the streak grow), and the result is
+// upgraded from StatusWarning to StatusError once the *prior* runs'
+// streak reaches ChronicMinRuns. UpgradeChronicStale checks History before
+// the current run is recorded, so the upgrade lags one run behind the
+// streak it reports afterward - hence 4 runs, not 3, to see StatusError.
+func TestRunnerRecordsHistoryAndUpgradesChronicStale(t *testing.T) {
+	h, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("opening history: %v", err)
+	}
+	defer h.Close()
+
+	check := &staleResultCheck{
+		BaseCheck:  BaseCheck{CheckName: "stale-attachments"},
+		attachment: StaleAttachment{Rig: "rig-a", MoleculeID: "mol-1"},
+	}
+	r := &Runner{
+		Checks:         []Check{check},
+		Jobs:           1,
+		History:        h,
+		ChronicWindow:  time.Hour,
+		ChronicMinRuns: 3,
+	}
+
+	var results []*CheckResult
+	for i := 0; i < 4; i++ {
+		results = r.Run(context.Background(), &CheckContext{TownRoot: t.TempDir()})
+	}
+
+	if results[0].Status != StatusError {
+		t.Errorf("expected the 4th consecutive stale run to be upgraded to StatusError, got %s", results[0].Status)
+	}
+
+	key := history.StaleKey{Rig: "rig-a", MoleculeID: "mol-1"}
+	streak, total, err := h.StaleStreak(key, time.Hour)
+	if err != nil {
+		t.Fatalf("StaleStreak: %v", err)
+	}
+	if streak != 4 || total != 4 {
+		t.Errorf("expected Run to have persisted all 4 runs to History, got streak=%d total=%d", streak, total)
+	}
+}
+
+// TestRunnerUpgradeRequiresMinConsecutiveRuns runs the stale finding only
+// three times with ChronicMinRuns=3 and verifies it stays a warning - since
+// UpgradeChronicStale only sees the prior two runs' streak at that point,
+// proving it isn't upgrading on every run regardless of streak.
+func TestRunnerUpgradeRequiresMinConsecutiveRuns(t *testing.T) {
+	h, err := history.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatalf("opening history: %v", err)
+	}
+	defer h.Close()
+
+	check := &staleResultCheck{
+		BaseCheck:  BaseCheck{CheckName: "stale-attachments"},
+		attachment: StaleAttachment{Rig: "rig-a", MoleculeID: "mol-1"},
+	}
+	r := &Runner{
+		Checks:         []Check{check},
+		Jobs:           1,
+		History:        h,
+		ChronicWindow:  time.Hour,
+		ChronicMinRuns: 3,
+	}
+
+	var results []*CheckResult
+	for i := 0; i < 3; i++ {
+		results = r.Run(context.Background(), &CheckContext{TownRoot: t.TempDir()})
+	}
+
+	if results[0].Status != StatusWarning {
+		t.Errorf("expected the result to stay a warning short of ChronicMinRuns, got %s", results[0].Status)
+	}
+}
@@ -1,11 +1,15 @@
 package doctor
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/heartbeat"
 )
 
 // DefaultStaleThreshold is the default time after which an attachment is considered stale.
@@ -17,6 +21,11 @@ const DefaultStaleThreshold = 1 * time.Hour
 type StaleAttachmentsCheck struct {
 	BaseCheck
 	Threshold time.Duration // Configurable staleness threshold
+
+	// checkRigFunc overrides how Run and RunParallel check a single rig, when set.
+	// Tests use this to exercise sharding/ordering without a real beads
+	// backend on disk.
+	checkRigFunc func(townRoot, rigName string, cutoff time.Time) ([]StaleAttachment, int, error)
 }
 
 // NewStaleAttachmentsCheck creates a new stale attachments check with the default threshold.
@@ -45,26 +54,135 @@ type StaleAttachment struct {
 	MoleculeTitle string
 	LastUpdated   time.Time
 	StaleDuration time.Duration
+
+	// WorkDir is the directory containing the .beads dir this attachment
+	// was found in; a Remediation needs it to build a beads.Client.
+	WorkDir string
+	// Worker is the polecat or crew worker name that owns the attachment,
+	// or empty for town-level attachments.
+	Worker string
+	// MoleculeFound is false when the attached molecule could not be
+	// fetched at all (as opposed to being found but stale).
+	MoleculeFound bool
 }
 
-// Run checks for stale attachments across all rigs.
-func (c *StaleAttachmentsCheck) Run(ctx *CheckContext) *CheckResult {
-	// If a specific rig is specified, only check that one
-	var rigsToCheck []string
+// remediation returns the automated action this finding suggests: detach
+// the attachment if its molecule is gone, otherwise nudge the polecat that
+// owns it.
+func (sa StaleAttachment) remediation() Remediation {
+	if !sa.MoleculeFound {
+		return Remediation{
+			Action:       ActionDetachAttachment,
+			WorkDir:      sa.WorkDir,
+			Rig:          sa.Rig,
+			PinnedBeadID: sa.PinnedBeadID,
+			MoleculeID:   sa.MoleculeID,
+			Reason:       "attached molecule no longer exists",
+		}
+	}
+	return Remediation{
+		Action:       ActionNudgePolecat,
+		WorkDir:      sa.WorkDir,
+		Rig:          sa.Rig,
+		PinnedBeadID: sa.PinnedBeadID,
+		MoleculeID:   sa.MoleculeID,
+		Reason:       fmt.Sprintf("no activity for %s", formatDuration(sa.StaleDuration)),
+	}
+}
+
+// stalledByHeartbeat cross-checks a polecat's heartbeat file against
+// mol.UpdatedAt being past cutoff. A molecule is only genuinely stalled if
+// its polecat's heartbeat also shows no real progress: either the PID is
+// gone, or the work-state hash has been unchanged for a full threshold
+// window. The "since when" comes from the heartbeat's own HashChangedAt
+// field (stamped by the polecat itself), not an in-process cache here - a
+// cache would reset every time `gt doctor` is invoked as a fresh process
+// and never actually catch a stuck polecat. Polecats with no heartbeat
+// file yet fall back to trusting UpdatedAt alone.
+func (c *StaleAttachmentsCheck) stalledByHeartbeat(polecatDir string, now time.Time) bool {
+	hb, err := heartbeat.Read(filepath.Join(polecatDir, heartbeat.FileName))
+	if err != nil {
+		return true
+	}
+
+	if !heartbeat.IsAlive(hb.PID) {
+		return true
+	}
+
+	if hb.HashChangedAt.IsZero() {
+		return false
+	}
+	return now.Sub(hb.HashChangedAt) >= c.Threshold
+}
+
+// rigsFor resolves which rigs a run should cover: just ctx.RigName if set,
+// otherwise every rig discovered under the town root.
+func (c *StaleAttachmentsCheck) rigsFor(ctx *CheckContext) ([]string, error) {
 	if ctx.RigName != "" {
-		rigsToCheck = []string{ctx.RigName}
-	} else {
-		// Discover all rigs
-		rigs, err := discoverRigs(ctx.TownRoot)
-		if err != nil {
-			return &CheckResult{
-				Name:    c.Name(),
-				Status:  StatusError,
-				Message: "Failed to discover rigs",
-				Details: []string{err.Error()},
+		return []string{ctx.RigName}, nil
+	}
+	return discoverRigs(ctx.TownRoot)
+}
+
+// buildResult assembles the final CheckResult from accumulated findings,
+// shared by the sequential and parallel code paths.
+func (c *StaleAttachmentsCheck) buildResult(staleAttachments []StaleAttachment, checkedCount int) *CheckResult {
+	if len(staleAttachments) > 0 {
+		details := make([]string, 0, len(staleAttachments))
+		for _, sa := range staleAttachments {
+			location := sa.Rig
+			if location == "" {
+				location = "town"
+			}
+			assigneeInfo := ""
+			if sa.Assignee != "" {
+				assigneeInfo = fmt.Sprintf(" (assignee: %s)", sa.Assignee)
 			}
+			details = append(details, fmt.Sprintf("%s: %s â†’ %s%s (stale for %s)",
+				location, sa.PinnedTitle, sa.MoleculeTitle, assigneeInfo, formatDuration(sa.StaleDuration)))
+		}
+
+		remediations := make([]Remediation, 0, len(staleAttachments))
+		for _, sa := range staleAttachments {
+			remediations = append(remediations, sa.remediation())
+		}
+
+		return &CheckResult{
+			Name:         c.Name(),
+			Status:       StatusWarning,
+			Message:      fmt.Sprintf("%d stale attachment(s) found (no activity for >%s)", len(staleAttachments), formatDuration(c.Threshold)),
+			Details:      details,
+			FixHint:      "Check if polecats are stuck or crashed. Use 'gt witness nudge <polecat>' or 'gt polecat kill <name>' if needed",
+			Remediations: remediations,
+			Data:         staleAttachments,
+		}
+	}
+
+	if checkedCount == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No attachments to check",
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusOK,
+		Message: fmt.Sprintf("Checked %d attachment(s), none stale", checkedCount),
+	}
+}
+
+// Run checks for stale attachments across all rigs.
+func (c *StaleAttachmentsCheck) Run(ctx *CheckContext) *CheckResult {
+	rigsToCheck, err := c.rigsFor(ctx)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "Failed to discover rigs",
+			Details: []string{err.Error()},
 		}
-		rigsToCheck = rigs
 	}
 
 	if len(rigsToCheck) == 0 {
@@ -91,53 +209,118 @@ func (c *StaleAttachmentsCheck) Run(ctx *CheckContext) *CheckResult {
 	}
 
 	// Also check town-level beads for pinned attachments
-	townStale, townChecked, err := c.checkBeadsDir(ctx.TownRoot, filepath.Join(ctx.TownRoot, ".beads"), cutoff)
+	townStale, townChecked, err := c.checkBeadsDir(filepath.Join(ctx.TownRoot, ".beads"), cutoff)
 	if err == nil {
 		staleAttachments = append(staleAttachments, townStale...)
 		checkedCount += townChecked
 	}
 
-	if len(staleAttachments) > 0 {
-		details := make([]string, 0, len(staleAttachments))
-		for _, sa := range staleAttachments {
-			location := sa.Rig
-			if location == "" {
-				location = "town"
-			}
-			assigneeInfo := ""
-			if sa.Assignee != "" {
-				assigneeInfo = fmt.Sprintf(" (assignee: %s)", sa.Assignee)
-			}
-			details = append(details, fmt.Sprintf("%s: %s â†’ %s%s (stale for %s)",
-				location, sa.PinnedTitle, sa.MoleculeTitle, assigneeInfo, formatDuration(sa.StaleDuration)))
-		}
+	return c.buildResult(staleAttachments, checkedCount)
+}
 
+// RunParallel implements ParallelCheck by sharding rigsToCheck across a
+// worker pool of at most jobs goroutines that pull rigs from a shared queue,
+// and emitting a partial result as each rig finishes. A slow rig never blocks reports from faster ones: each
+// worker pushes its findings onto a channel as soon as it's done, and the
+// final result only merges everything once every rig has reported in. jobs
+// caps the worker pool size; <= 0 falls back to runtime.GOMAXPROCS(0).
+func (c *StaleAttachmentsCheck) RunParallel(ctx context.Context, checkCtx *CheckContext, jobs int, emit func(partial *CheckResult)) *CheckResult {
+	rigsToCheck, err := c.rigsFor(checkCtx)
+	if err != nil {
 		return &CheckResult{
 			Name:    c.Name(),
-			Status:  StatusWarning,
-			Message: fmt.Sprintf("%d stale attachment(s) found (no activity for >%s)", len(staleAttachments), formatDuration(c.Threshold)),
-			Details: details,
-			FixHint: "Check if polecats are stuck or crashed. Use 'gt witness nudge <polecat>' or 'gt polecat kill <name>' if needed",
+			Status:  StatusError,
+			Message: "Failed to discover rigs",
+			Details: []string{err.Error()},
 		}
 	}
 
-	if checkedCount == 0 {
+	if len(rigsToCheck) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusOK,
-			Message: "No attachments to check",
+			Message: "No rigs configured",
 		}
 	}
 
-	return &CheckResult{
-		Name:    c.Name(),
-		Status:  StatusOK,
-		Message: fmt.Sprintf("Checked %d attachment(s), none stale", checkedCount),
+	cutoff := time.Now().Add(-c.Threshold)
+
+	type rigResult struct {
+		rig     string
+		stale   []StaleAttachment
+		checked int
+		err     error
+	}
+
+	jobQueue := make(chan string, len(rigsToCheck))
+	for _, rigName := range rigsToCheck {
+		jobQueue <- rigName
+	}
+	close(jobQueue)
+
+	results := make(chan rigResult, len(rigsToCheck))
+	workers := jobs
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(rigsToCheck) {
+		workers = len(rigsToCheck)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rigName := range jobQueue {
+				if ctx.Err() != nil {
+					results <- rigResult{rig: rigName, err: ctx.Err()}
+					continue
+				}
+				stale, checked, err := c.checkRig(checkCtx.TownRoot, rigName, cutoff)
+				results <- rigResult{rig: rigName, stale: stale, checked: checked, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var staleAttachments []StaleAttachment
+	var checkedCount int
+	done := 0
+	for rr := range results {
+		done++
+		if rr.err != nil {
+			continue
+		}
+		staleAttachments = append(staleAttachments, rr.stale...)
+		checkedCount += rr.checked
+		if emit != nil {
+			emit(&CheckResult{
+				Name:    c.Name(),
+				Status:  StatusOK,
+				Message: fmt.Sprintf("checked rig %s (%d/%d rigs done)", rr.rig, done, len(rigsToCheck)),
+			})
+		}
 	}
+
+	townStale, townChecked, err := c.checkBeadsDir(filepath.Join(checkCtx.TownRoot, ".beads"), cutoff)
+	if err == nil {
+		staleAttachments = append(staleAttachments, townStale...)
+		checkedCount += townChecked
+	}
+
+	return c.buildResult(staleAttachments, checkedCount)
 }
 
 // checkRig checks a single rig for stale attachments.
 func (c *StaleAttachmentsCheck) checkRig(townRoot, rigName string, cutoff time.Time) ([]StaleAttachment, int, error) {
+	if c.checkRigFunc != nil {
+		return c.checkRigFunc(townRoot, rigName, cutoff)
+	}
 	// Check rig-level beads and polecats
 	rigPath := filepath.Join(townRoot, rigName)
 
@@ -156,7 +339,7 @@ func (c *StaleAttachmentsCheck) checkRig(townRoot, rigName string, cutoff time.T
 		polecatPath := filepath.Dir(beadsPath)
 		polecatName := filepath.Base(polecatPath)
 
-		stale, checked, err := c.checkBeadsDirWithContext(rigPath, beadsPath, cutoff, rigName, polecatName)
+		stale, checked, err := c.checkBeadsDirWithContext(beadsPath, cutoff, rigName, polecatName)
 		if err != nil {
 			continue
 		}
@@ -172,7 +355,7 @@ func (c *StaleAttachmentsCheck) checkRig(townRoot, rigName string, cutoff time.T
 			workerPath := filepath.Dir(beadsPath)
 			workerName := filepath.Base(workerPath)
 
-			stale, checked, err := c.checkBeadsDirWithContext(rigPath, beadsPath, cutoff, rigName, "crew/"+workerName)
+			stale, checked, err := c.checkBeadsDirWithContext(beadsPath, cutoff, rigName, "crew/"+workerName)
 			if err != nil {
 				continue
 			}
@@ -185,12 +368,12 @@ func (c *StaleAttachmentsCheck) checkRig(townRoot, rigName string, cutoff time.T
 }
 
 // checkBeadsDir checks a beads directory for stale attachments.
-func (c *StaleAttachmentsCheck) checkBeadsDir(townRoot, beadsDir string, cutoff time.Time) ([]StaleAttachment, int, error) {
-	return c.checkBeadsDirWithContext(townRoot, beadsDir, cutoff, "", "")
+func (c *StaleAttachmentsCheck) checkBeadsDir(beadsDir string, cutoff time.Time) ([]StaleAttachment, int, error) {
+	return c.checkBeadsDirWithContext(beadsDir, cutoff, "", "")
 }
 
 // checkBeadsDirWithContext checks a beads directory for stale attachments with rig context.
-func (c *StaleAttachmentsCheck) checkBeadsDirWithContext(workDir, beadsDir string, cutoff time.Time, rigName, workerName string) ([]StaleAttachment, int, error) {
+func (c *StaleAttachmentsCheck) checkBeadsDirWithContext(beadsDir string, cutoff time.Time, rigName, workerName string) ([]StaleAttachment, int, error) {
 	// Create beads client for the directory containing .beads
 	parentDir := filepath.Dir(beadsDir)
 	bd := beads.New(parentDir)
@@ -230,6 +413,9 @@ func (c *StaleAttachmentsCheck) checkBeadsDirWithContext(workDir, beadsDir strin
 				MoleculeTitle: "(molecule not found)",
 				LastUpdated:   time.Time{},
 				StaleDuration: time.Since(cutoff) + c.Threshold, // Report as stale
+				WorkDir:       parentDir,
+				Worker:        workerName,
+				MoleculeFound: false,
 			})
 			continue
 		}
@@ -241,8 +427,10 @@ func (c *StaleAttachmentsCheck) checkBeadsDirWithContext(workDir, beadsDir strin
 		}
 
 		// Check if the molecule is stale (hasn't been updated since cutoff)
-		// Only check molecules that are still in progress
-		if mol.Status == "in_progress" && updatedAt.Before(cutoff) {
+		// Only check molecules that are still in progress. UpdatedAt alone
+		// lies in both directions, so also cross-check the owning
+		// polecat's heartbeat before reporting.
+		if mol.Status == "in_progress" && updatedAt.Before(cutoff) && c.stalledByHeartbeat(parentDir, time.Now()) {
 			staleAttachments = append(staleAttachments, StaleAttachment{
 				Rig:           rigName,
 				PinnedBeadID:  pinned.ID,
@@ -252,6 +440,9 @@ func (c *StaleAttachmentsCheck) checkBeadsDirWithContext(workDir, beadsDir strin
 				MoleculeTitle: mol.Title,
 				LastUpdated:   updatedAt,
 				StaleDuration: time.Since(updatedAt),
+				WorkDir:       parentDir,
+				Worker:        workerName,
+				MoleculeFound: true,
 			})
 		}
 	}
@@ -0,0 +1,80 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRunParallelShardsAcrossRigs exercises StaleAttachmentsCheck.RunParallel
+// itself (not just the outer Runner) with several rigs, using checkRigFunc
+// to stand in for a real beads backend. It verifies every rig is checked
+// exactly once, findings from all rigs are merged into the final result,
+// and one partial is emitted per rig.
+func TestRunParallelShardsAcrossRigs(t *testing.T) {
+	rigs := []string{"rig-a", "rig-b", "rig-c", "rig-d"}
+
+	var mu sync.Mutex
+	var checkedRigs []string
+
+	c := &StaleAttachmentsCheck{
+		BaseCheck: BaseCheck{CheckName: "stale-attachments"},
+		Threshold: time.Hour,
+		checkRigFunc: func(townRoot, rigName string, cutoff time.Time) ([]StaleAttachment, int, error) {
+			mu.Lock()
+			checkedRigs = append(checkedRigs, rigName)
+			mu.Unlock()
+
+			// rig-b reports one stale attachment; the rest report none.
+			if rigName == "rig-b" {
+				return []StaleAttachment{{
+					Rig:           rigName,
+					PinnedBeadID:  "bd-1",
+					MoleculeID:    "mol-1",
+					MoleculeFound: true,
+					StaleDuration: 2 * time.Hour,
+				}}, 1, nil
+			}
+			return nil, 1, nil
+		},
+	}
+
+	var partialMu sync.Mutex
+	var partials []string
+	emit := func(partial *CheckResult) {
+		partialMu.Lock()
+		defer partialMu.Unlock()
+		partials = append(partials, partial.Message)
+	}
+
+	townRoot := t.TempDir()
+	for _, rig := range rigs {
+		if err := os.MkdirAll(filepath.Join(townRoot, rig, "polecats"), 0o755); err != nil {
+			t.Fatalf("setting up rig dir: %v", err)
+		}
+	}
+
+	checkCtx := &CheckContext{TownRoot: townRoot}
+	result := c.RunParallel(context.Background(), checkCtx, 2, emit)
+
+	if len(checkedRigs) != len(rigs) {
+		t.Fatalf("expected all %d rigs to be checked, got %d: %v", len(rigs), len(checkedRigs), checkedRigs)
+	}
+	seen := map[string]bool{}
+	for _, r := range checkedRigs {
+		if seen[r] {
+			t.Fatalf("rig %s was checked more than once", r)
+		}
+		seen[r] = true
+	}
+
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning with one stale attachment found, got %v (%s)", result.Status, result.Message)
+	}
+	if len(partials) != len(rigs) {
+		t.Fatalf("expected one partial emitted per rig, got %d: %v", len(partials), partials)
+	}
+}
@@ -0,0 +1,63 @@
+// Package heartbeat lets a polecat record liveness that is independent of
+// whatever it happens to be writing to its molecules: a PID, a wall-clock
+// timestamp, a monotonic tick counter, and a content hash of its in-memory
+// work-state summary. Doctor checks read these files to tell "stuck" apart
+// from "alive but between flushes."
+package heartbeat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// FileName is the heartbeat file written under a polecat's directory.
+const FileName = ".heartbeat"
+
+// Heartbeat is the liveness record a polecat writes periodically.
+type Heartbeat struct {
+	PID       int       `json:"pid"`
+	WallClock time.Time `json:"wall_clock"`
+	Tick      uint64    `json:"tick"`
+	// Hash is a hex-encoded BLAKE2b-256 hash of the polecat's in-memory
+	// work-state summary. It changes whenever the polecat's actual work
+	// progresses, even if it rewrites a molecule with unchanged content.
+	Hash string `json:"hash"`
+	// HashChangedAt is the wall-clock time Hash last changed. The polecat
+	// stamps this itself (only updating it when Hash changes), so readers
+	// can tell how long the work-state has been stuck without having to
+	// remember the previous hash across separate process invocations.
+	HashChangedAt time.Time `json:"hash_changed_at"`
+}
+
+// Write atomically writes hb to path (typically
+// <rig>/polecats/<name>/.heartbeat).
+func Write(path string, hb Heartbeat) error {
+	data, err := json.Marshal(hb)
+	if err != nil {
+		return fmt.Errorf("marshaling heartbeat: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing heartbeat: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("installing heartbeat: %w", err)
+	}
+	return nil
+}
+
+// Read reads and parses the heartbeat file at path.
+func Read(path string) (*Heartbeat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hb Heartbeat
+	if err := json.Unmarshal(data, &hb); err != nil {
+		return nil, fmt.Errorf("parsing heartbeat %s: %w", path, err)
+	}
+	return &hb, nil
+}
@@ -0,0 +1,19 @@
+//go:build !unix
+
+package heartbeat
+
+import "os"
+
+// IsAlive reports whether pid refers to a running process. Non-unix
+// platforms have no portable signal-0 probe, so this only checks that the
+// OS will hand back a process handle for pid; it can't tell a live process
+// from a dead one whose PID hasn't been recycled yet. DeadPolecatCheck and
+// StaleAttachmentsCheck rely on this, so on these platforms they can miss a
+// dead polecat.
+func IsAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	_, err := os.FindProcess(pid)
+	return err == nil
+}
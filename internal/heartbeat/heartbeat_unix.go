@@ -0,0 +1,22 @@
+//go:build unix
+
+package heartbeat
+
+import (
+	"os"
+	"syscall"
+)
+
+// IsAlive reports whether pid refers to a running process, by sending it
+// signal 0 (which performs the existence and permission checks without
+// actually signaling the process).
+func IsAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}